@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// Encoding identifies how a BroadcastRequest's Payload is encoded on the
+// wire, so a receiver knows which Codec to run it through before handing it
+// to a SubscribeBroadcastEvent handler.
+type Encoding string
+
+const (
+	// EncodingRaw means the payload is plain, uncompressed JSON. This is the
+	// default and keeps wire compatibility with peers that predate codecs.
+	EncodingRaw Encoding = ""
+
+	// EncodingGzip means the payload is compressed with gzip.
+	EncodingGzip Encoding = "gzip"
+)
+
+// Codec compresses and decompresses broadcast payloads for one Encoding.
+// EncodingRaw and EncodingGzip are registered on every Document; callers can
+// register additional codecs such as zstd or snappy with RegisterCodec.
+type Codec interface {
+	Encode(payload []byte) ([]byte, error)
+	Decode(payload []byte) ([]byte, error)
+}
+
+type rawCodec struct{}
+
+func (rawCodec) Encode(payload []byte) ([]byte, error) { return payload, nil }
+
+func (rawCodec) Decode(payload []byte) ([]byte, error) { return payload, nil }
+
+type gzipCodec struct{}
+
+func (gzipCodec) Encode(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decode(payload []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
+
+// builtinCodecs returns the codecs every Document is seeded with.
+func builtinCodecs() map[Encoding]Codec {
+	return map[Encoding]Codec{
+		EncodingRaw:  rawCodec{},
+		EncodingGzip: gzipCodec{},
+	}
+}
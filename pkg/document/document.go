@@ -21,9 +21,8 @@ import (
 	gojson "encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"sync"
 
-	"github.com/yorkie-team/yorkie/api/types"
 	"github.com/yorkie-team/yorkie/pkg/document/change"
 	"github.com/yorkie-team/yorkie/pkg/document/crdt"
 	"github.com/yorkie-team/yorkie/pkg/document/innerpresence"
@@ -32,7 +31,6 @@ import (
 	"github.com/yorkie-team/yorkie/pkg/document/presence"
 	"github.com/yorkie-team/yorkie/pkg/document/time"
 	"github.com/yorkie-team/yorkie/pkg/resource"
-	"github.com/yorkie-team/yorkie/pkg/schema"
 )
 
 var (
@@ -44,6 +42,20 @@ var (
 
 	// ErrSchemaValidationFailed is returned when the document schema validation failed.
 	ErrSchemaValidationFailed = errors.New("schema validation failed")
+
+	// ErrUnregisteredCodec is returned when a BroadcastRequest or broadcast
+	// event names an Encoding that has no Codec registered for it.
+	ErrUnregisteredCodec = errors.New("unregistered codec")
+
+	// ErrBroadcastRequestDropped is returned when a BroadcastRequest was not
+	// enqueued for any subscriber, so nothing will ever call Respond for it.
+	// This happens when Broadcast is called with no subscriber registered at
+	// all, or when every matching subscriber's overflow policy dropped the
+	// request instead of queuing it (DropNewest discarding it, or CloseOnLag
+	// tearing the subscription down; DropOldest always queues the incoming
+	// request by evicting an older one). Without this check, such a
+	// request would leave its caller blocked on Broadcast forever.
+	ErrBroadcastRequestDropped = errors.New("broadcast request was not delivered to any subscriber")
 )
 
 // DocEvent represents the event that occurred in the document.
@@ -73,6 +85,34 @@ const (
 type BroadcastRequest struct {
 	Topic   string
 	Payload []byte
+
+	// Encoding identifies the Codec Payload was encoded with, so the
+	// receiver can decode it before passing it to a SubscribeBroadcastEvent
+	// handler. EncodingRaw means Payload is plain, uncompressed JSON.
+	Encoding Encoding
+
+	// respCh carries the result of handling this request back to the
+	// goroutine that called Document.Broadcast. Each request owns its own
+	// respCh, so concurrent Broadcast calls no longer serialize on a single
+	// shared response channel.
+	respCh chan error
+
+	// respOnce guards respCh against more than one send. SubscribeBroadcastRequests
+	// allows more than one goroutine to watch the same BroadcastRequest, so
+	// more than one of them may call Respond for it; without this, every
+	// Respond call past the first would block forever on the full,
+	// buffer-1 respCh and leak the calling goroutine.
+	respOnce *sync.Once
+}
+
+// Respond delivers the result of handling this request back to the
+// Document.Broadcast call that produced it. It is safe to call from more
+// than one subscriber of the same BroadcastRequest; only the first call's
+// err is delivered, later calls are no-ops.
+func (r BroadcastRequest) Respond(err error) {
+	r.respOnce.Do(func() {
+		r.respCh <- err
+	})
 }
 
 // Option configures Options.
@@ -84,6 +124,36 @@ type Options struct {
 	// NOTE(hackerwins): This is temporary option. We need to remove this option
 	// after introducing the garbage collection based on the version vector.
 	DisableGC bool
+
+	// BroadcastCodec is the Encoding Broadcast compresses payloads with when
+	// the caller does not choose one via WithCodec. Defaults to EncodingRaw.
+	BroadcastCodec Encoding
+
+	// BroadcastCodecThreshold is the minimum payload size in bytes at which
+	// Broadcast applies BroadcastCodec. Smaller payloads are always sent as
+	// EncodingRaw, since compressing them would cost more than it saves.
+	BroadcastCodecThreshold int
+
+	// Store is the local persistence backend the document keeps its local
+	// changes and snapshots in, so it can be rehydrated with Resume after a
+	// restart. Documents created with New leave this nil, meaning state
+	// lives in memory only, as before.
+	Store Store
+
+	// StrictSchema disables automatic schema migration on ApplyChangePack,
+	// restoring the original behavior where a document whose data no longer
+	// matches its rules simply fails validation on the next Update.
+	StrictSchema bool
+}
+
+// WithStrictSchema disables the automatic schema migration ApplyChangePack
+// otherwise runs, restoring the original behavior: a document whose data no
+// longer satisfies its schema rules fails validation on the next Update
+// instead of being migrated forward automatically.
+func WithStrictSchema() Option {
+	return func(o *Options) {
+		o.StrictSchema = true
+	}
 }
 
 // WithDisableGC configures the document to disable garbage collection.
@@ -93,6 +163,17 @@ func WithDisableGC() Option {
 	}
 }
 
+// WithBroadcastCodec sets the default codec Broadcast uses for payloads at
+// least threshold bytes long, when the caller does not choose a codec with
+// WithCodec. The default remains EncodingRaw, so existing callers keep
+// sending uncompressed JSON unless they opt in.
+func WithBroadcastCodec(encoding Encoding, threshold int) Option {
+	return func(o *Options) {
+		o.BroadcastCodec = encoding
+		o.BroadcastCodecThreshold = threshold
+	}
+}
+
 // Document represents a document accessible to the user.
 //
 // How document works:
@@ -118,17 +199,47 @@ type Document struct {
 	// MaxSizeLimit is the maximum size of a document in bytes.
 	MaxSizeLimit int
 
-	// SchemaRules is the rules of the schema of the document.
-	SchemaRules []types.Rule
+	// SchemaVersion is the schema version this document validates against,
+	// once every applicable migration in SchemaMigrations has been replayed.
+	// A document with no SchemaMigrations configured is implicitly at
+	// version 0 and is never validated, as before schema support existed.
+	SchemaVersion uint32
+
+	// SchemaMigrations are the ordered migrations Document replays to bring
+	// a document's tree from whatever schema version it was stamped with up
+	// to SchemaVersion. See Migration and WithStrictSchema.
+	SchemaMigrations []Migration
+
+	// events is the hub that fans events occurred in the document out to
+	// every Subscription independently, each with its own bounded queue.
+	events *hub[DocEvent]
+
+	// defaultEvents is the Subscription backing the legacy Events() channel.
+	defaultEvents *Subscription[DocEvent]
+
+	// broadcasts is the hub that fans BroadcastRequests out to the
+	// goroutines (e.g. the realtime server) watching this document.
+	broadcasts *hub[BroadcastRequest]
+
+	// defaultBroadcasts is the Subscription backing the legacy
+	// BroadcastRequests() channel.
+	defaultBroadcasts *Subscription[BroadcastRequest]
 
-	// events is the channel to send events that occurred in the document.
-	events chan DocEvent
+	// codecsMu guards codecs, since RegisterCodec can run concurrently with
+	// Broadcast/HandleBroadcastEvent reading it from other goroutines, which
+	// is exactly the concurrent-broadcast usage the subscription hub exists
+	// to support.
+	codecsMu sync.RWMutex
 
-	// broadcastRequests is the send-only channel to send broadcast requests.
-	broadcastRequests chan BroadcastRequest
+	// codecs is the set of Codecs Broadcast and HandleBroadcastEvent may
+	// encode or decode a payload with, keyed by Encoding. EncodingRaw and
+	// EncodingGzip are always present; more can be added with RegisterCodec.
+	codecs map[Encoding]Codec
 
-	// broadcastResponses is the receive-only channel to receive broadcast responses.
-	broadcastResponses chan error
+	// store is the local persistence backend this document keeps its local
+	// changes and snapshots in. It is nil unless configured via WithStore or
+	// Resume.
+	store Store
 
 	// broadcastEventHandlers is a map of registered event handlers for events.
 	broadcastEventHandlers map[string]func(
@@ -143,18 +254,72 @@ func New(key key.Key, opts ...Option) *Document {
 		opt(&options)
 	}
 
+	events := newHub[DocEvent]()
+	broadcasts := newHub[BroadcastRequest]()
+
 	return &Document{
-		doc:                NewInternalDocument(key),
-		options:            options,
-		events:             make(chan DocEvent, 1),
-		broadcastRequests:  make(chan BroadcastRequest, 1),
-		broadcastResponses: make(chan error, 1),
+		doc:               NewInternalDocument(key),
+		options:           options,
+		events:            events,
+		defaultEvents:     events.subscribe(defaultEventBufSize, DropOldest, nil),
+		broadcasts:        broadcasts,
+		defaultBroadcasts: broadcasts.subscribe(defaultBroadcastBufSize, CloseOnLag, nil),
+		codecs:            builtinCodecs(),
+		store:             options.Store,
 		broadcastEventHandlers: make(map[string]func(
 			topic, publisher string,
 			payload []byte) error),
 	}
 }
 
+// defaultBroadcastBufSize is the queue size of the Subscription backing the
+// legacy BroadcastRequests() channel.
+const defaultBroadcastBufSize = 64
+
+// Resume creates a Document for k rehydrated from store's most recently
+// persisted state, so a client that attaches right after startup does not
+// need to re-confirm its own pending local changes with the server. If
+// store has nothing saved for k yet, Resume behaves like New with
+// WithStore(store).
+//
+// Resume only eliminates re-work for local changes: Store currently
+// persists a snapshot (on SaveSnapshot) and the local changes appended
+// between snapshots, but not the incremental remote changes a pack applies
+// without a snapshot, so cp/vv can still be behind the server's. The
+// caller still needs its usual sync round-trip to catch the document up on
+// any remote changes that landed since the last persisted snapshot; Resume
+// just ensures that round-trip does not also re-confirm local changes the
+// process already durably recorded.
+func Resume(k key.Key, store Store, opts ...Option) (*Document, error) {
+	snapshot, localChanges, cp, vv, err := store.LoadLatest(k)
+	if err != nil {
+		return nil, err
+	}
+
+	d := New(k, append(opts, WithStore(store))...)
+
+	if len(snapshot) > 0 {
+		if err := d.doc.applySnapshot(snapshot, vv); err != nil {
+			return nil, err
+		}
+	}
+	d.doc.checkpoint = cp
+	d.doc.localChanges = localChanges
+
+	// NOTE: localChanges are not yet confirmed by the server, so they must
+	// be replayed on top of the snapshot, the same way ApplyChangePack
+	// replays them after applying a fresh snapshot from the server.
+	// Otherwise Root()/Marshal() would silently drop every local edit made
+	// before the process restarted.
+	if len(localChanges) > 0 {
+		if err := d.applyChanges(localChanges); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
 // Update executes the given updater to update this document.
 func (d *Document) Update(
 	updater func(root *json.Object, p *presence.Presence) error,
@@ -185,16 +350,11 @@ func (d *Document) Update(
 		return err
 	}
 
-	if !ctx.IsPresenceOnlyChange() && len(d.SchemaRules) > 0 {
-		result := schema.ValidateYorkieRuleset(d.cloneRoot.Object(), d.SchemaRules)
-		if !result.Valid {
-			var errorMessages []string
-			for _, err := range result.Errors {
-				errorMessages = append(errorMessages, err.Message)
-			}
+	if !ctx.IsPresenceOnlyChange() {
+		if err := d.validateSchema(); err != nil {
 			d.cloneRoot = nil
 			d.clonePresences = nil
-			return fmt.Errorf("%w: %s", ErrSchemaValidationFailed, strings.Join(errorMessages, ", "))
+			return err
 		}
 	}
 
@@ -209,6 +369,19 @@ func (d *Document) Update(
 
 	if ctx.HasChange() {
 		c := ctx.ToChange()
+
+		// NOTE: Persist before mutating in-memory state: if AppendLocalChange
+		// fails, Update returns an error with the document exactly as it was
+		// before the call, so a caller that retries on error cannot
+		// double-apply a change the store never durably recorded.
+		if d.store != nil {
+			if err := d.store.AppendLocalChange(d.doc.key, c); err != nil {
+				d.cloneRoot = nil
+				d.clonePresences = nil
+				return err
+			}
+		}
+
 		if err := c.Execute(d.doc.root, d.doc.presences); err != nil {
 			return err
 		}
@@ -226,6 +399,20 @@ func (d *Document) ApplyChangePack(pack *change.Pack) error {
 	hasSnapshot := len(pack.Snapshot) > 0
 
 	if hasSnapshot {
+		// NOTE: Persist before mutating in-memory state, so that if
+		// SaveSnapshot fails, ApplyChangePack returns an error with the
+		// document exactly as it was before the call.
+		if d.store != nil {
+			if err := d.store.SaveSnapshot(
+				d.doc.key,
+				pack.Checkpoint,
+				pack.VersionVector,
+				pack.Snapshot,
+			); err != nil {
+				return err
+			}
+		}
+
 		d.cloneRoot = nil
 		d.clonePresences = nil
 		if err := d.doc.applySnapshot(pack.Snapshot, pack.VersionVector); err != nil {
@@ -238,6 +425,11 @@ func (d *Document) ApplyChangePack(pack *change.Pack) error {
 	}
 
 	// 02. Remove local changes applied to server.
+	if d.store != nil {
+		if err := d.store.TruncateLocalChanges(d.doc.key, pack.Checkpoint.ClientSeq); err != nil {
+			return err
+		}
+	}
 	for d.HasLocalChanges() {
 		c := d.doc.localChanges[0]
 		if c.ClientSeq() > pack.Checkpoint.ClientSeq {
@@ -247,20 +439,41 @@ func (d *Document) ApplyChangePack(pack *change.Pack) error {
 	}
 
 	if len(pack.Snapshot) > 0 {
+		// NOTE: SaveSnapshot starts a new local change log for the key, so
+		// the local changes the server has not confirmed yet are appended
+		// again; otherwise Resume would lose them.
+		if d.store != nil {
+			for _, c := range d.doc.localChanges {
+				if err := d.store.AppendLocalChange(d.doc.key, c); err != nil {
+					return err
+				}
+			}
+		}
 		if err := d.applyChanges(d.doc.localChanges); err != nil {
 			return err
 		}
 	}
 
-	// 03. Update the checkpoint.
+	// 03. Replay any pending schema migrations now that remote and local
+	// changes have both been reconciled. Running this before the local
+	// changes replay above would have the replay re-execute the migration's
+	// own change a second time, since a change it just appended is never
+	// trimmed by step 02.
+	if !d.options.StrictSchema && len(d.SchemaMigrations) > 0 {
+		if err := d.migrateIfNeeded(); err != nil {
+			return err
+		}
+	}
+
+	// 04. Update the checkpoint.
 	d.doc.checkpoint = d.doc.checkpoint.Forward(pack.Checkpoint)
 
-	// 04. Do Garbage collection.
+	// 05. Do Garbage collection.
 	if !d.options.DisableGC && !hasSnapshot {
 		d.GarbageCollect(pack.VersionVector)
 	}
 
-	// 05. Update the status.
+	// 06. Update the status.
 	if pack.IsRemoved {
 		d.SetStatus(StatusRemoved)
 	}
@@ -285,7 +498,7 @@ func (d *Document) applyChanges(changes []*change.Change) error {
 	}
 
 	for _, e := range events {
-		d.events <- e
+		d.events.publish(e)
 	}
 	return nil
 }
@@ -452,33 +665,124 @@ func (d *Document) RemoveOnlineClient(clientID string) {
 	d.doc.RemoveOnlineClient(clientID)
 }
 
-// Events returns the events of this document.
+// Events returns the channel of every DocEvent, delivered with the default
+// overflow policy (DropOldest). Use Subscribe instead if you need to filter
+// by event type or need a different overflow policy.
 func (d *Document) Events() <-chan DocEvent {
-	return d.events
+	return d.defaultEvents.C()
+}
+
+// Subscribe returns a Subscription that receives the DocEvents matching the
+// given options. Each Subscription owns its own bounded queue, so a slow
+// subscriber can neither block applyChanges nor starve other subscribers.
+func (d *Document) Subscribe(opts ...SubscribeOptionFunc) *Subscription[DocEvent] {
+	o := newSubscribeOption(opts...)
+	return d.events.subscribe(o.bufSize, o.policy, o.matches)
 }
 
-// BroadcastRequests returns the broadcast requests of this document.
+// BroadcastRequests returns the channel of BroadcastRequests issued by
+// Document.Broadcast, delivered with the default overflow policy
+// (CloseOnLag). Use SubscribeBroadcastRequests instead if more than one
+// goroutine needs to watch the same stream of requests.
 func (d *Document) BroadcastRequests() <-chan BroadcastRequest {
-	return d.broadcastRequests
+	return d.defaultBroadcasts.C()
+}
+
+// SubscribeBroadcastRequests returns a Subscription of BroadcastRequests,
+// letting multiple goroutines (e.g. more than one realtime server watcher)
+// consume the same stream of requests independently.
+func (d *Document) SubscribeBroadcastRequests(
+	bufSize int,
+	policy OverflowPolicy,
+) *Subscription[BroadcastRequest] {
+	return d.broadcasts.subscribe(bufSize, policy, nil)
 }
 
-// BroadcastResponses returns the broadcast responses of this document.
-func (d *Document) BroadcastResponses() chan error {
-	return d.broadcastResponses
+// BroadcastOption configures a single Document.Broadcast call.
+type BroadcastOption func(*broadcastOptions)
+
+type broadcastOptions struct {
+	codec *Encoding
 }
 
-// Broadcast encodes the given payload and sends a Broadcast request.
-func (d *Document) Broadcast(topic string, payload any) error {
+// WithCodec chooses the Encoding a single Broadcast call compresses its
+// payload with, overriding the document's WithBroadcastCodec default.
+// Payloads shorter than the document's BroadcastCodecThreshold are still
+// sent as EncodingRaw.
+func WithCodec(encoding Encoding) BroadcastOption {
+	return func(o *broadcastOptions) {
+		o.codec = &encoding
+	}
+}
+
+// RegisterCodec adds or replaces the Codec used for the given Encoding, so
+// Broadcast and HandleBroadcastEvent can use codecs such as zstd or snappy
+// beyond the built-in EncodingRaw and EncodingGzip.
+func (d *Document) RegisterCodec(encoding Encoding, codec Codec) {
+	d.codecsMu.Lock()
+	defer d.codecsMu.Unlock()
+	d.codecs[encoding] = codec
+}
+
+// codec returns the Codec registered for encoding, if any.
+func (d *Document) codec(encoding Encoding) (Codec, bool) {
+	d.codecsMu.RLock()
+	defer d.codecsMu.RUnlock()
+	codec, ok := d.codecs[encoding]
+	return codec, ok
+}
+
+// Broadcast encodes the given payload, optionally compressing it with a
+// Codec, and publishes a BroadcastRequest to the broadcast hub, then waits
+// for the goroutine handling it to call Respond. Each call owns its own
+// response channel, so multiple goroutines can have a Broadcast in flight
+// at the same time instead of serializing on a single shared response
+// channel. By default the payload is sent as uncompressed JSON; pass
+// WithCodec to compress it, or set a document-wide default with
+// WithBroadcastCodec.
+func (d *Document) Broadcast(topic string, payload any, opts ...BroadcastOption) error {
 	marshaled, err := gojson.Marshal(payload)
 	if err != nil {
 		return ErrUnsupportedPayloadType
 	}
 
-	d.broadcastRequests <- BroadcastRequest{
-		Topic:   topic,
-		Payload: marshaled,
+	bo := &broadcastOptions{}
+	for _, opt := range opts {
+		opt(bo)
+	}
+
+	encoding := d.options.BroadcastCodec
+	if bo.codec != nil {
+		encoding = *bo.codec
+	}
+
+	if encoding != EncodingRaw && len(marshaled) >= d.options.BroadcastCodecThreshold {
+		codec, ok := d.codec(encoding)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnregisteredCodec, encoding)
+		}
+
+		encoded, err := codec.Encode(marshaled)
+		if err != nil {
+			return err
+		}
+		marshaled = encoded
+	} else {
+		encoding = EncodingRaw
+	}
+
+	respCh := make(chan error, 1)
+	delivered := d.broadcasts.publish(BroadcastRequest{
+		Topic:    topic,
+		Payload:  marshaled,
+		Encoding: encoding,
+		respCh:   respCh,
+		respOnce: &sync.Once{},
+	})
+	if !delivered {
+		return ErrBroadcastRequestDropped
 	}
-	return <-d.broadcastResponses
+	return <-respCh
 }
 
 // SubscribeBroadcastEvent subscribes to the given topic and registers
@@ -507,6 +811,36 @@ func (d *Document) BroadcastEventHandlers() map[string]func(
 	return d.broadcastEventHandlers
 }
 
+// HandleBroadcastEvent decodes payload according to encoding and dispatches
+// it to the handler registered for topic via SubscribeBroadcastEvent, so
+// handlers always receive plain bytes regardless of which Codec the
+// publisher chose. It is a no-op if no handler is registered for topic.
+func (d *Document) HandleBroadcastEvent(
+	topic, publisher string,
+	encoding Encoding,
+	payload []byte,
+) error {
+	handler, ok := d.broadcastEventHandlers[topic]
+	if !ok {
+		return nil
+	}
+
+	if encoding != EncodingRaw {
+		codec, ok := d.codec(encoding)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnregisteredCodec, encoding)
+		}
+
+		decoded, err := codec.Decode(payload)
+		if err != nil {
+			return err
+		}
+		payload = decoded
+	}
+
+	return handler(topic, publisher, payload)
+}
+
 func (d *Document) setInternalDoc(internalDoc *InternalDocument) {
 	d.doc = internalDoc
 }
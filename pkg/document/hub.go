@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSubscriptionLagged is the error a Subscription created with
+// CloseOnLag carries when the hub had to close it instead of dropping one
+// of its queued items.
+var ErrSubscriptionLagged = errors.New("subscription lagged behind and was closed")
+
+// OverflowPolicy decides what a hub does for a subscriber whose queue is
+// already full when a new item is published.
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the oldest queued item to make room for the new
+	// one. This is the default: a subscriber sees the freshest state even if
+	// it misses items in between.
+	DropOldest OverflowPolicy = iota
+
+	// DropNewest discards the incoming item, leaving the subscriber's queue
+	// untouched. Useful when older items must not be skipped.
+	DropNewest
+
+	// CloseOnLag closes the subscription with ErrSubscriptionLagged instead
+	// of dropping anything silently. Use this when losing an item is worse
+	// than disconnecting the subscriber.
+	CloseOnLag
+)
+
+// Subscription is a single consumer's bounded view of the items published to
+// a hub. Each Subscription owns its own queue, so a slow subscriber can
+// never block the publisher or any other subscription.
+type Subscription[T any] struct {
+	id     int64
+	hub    *hub[T]
+	queue  chan T
+	policy OverflowPolicy
+	filter func(T) bool
+
+	mu        sync.Mutex
+	err       error
+	closeOnce sync.Once
+}
+
+// C returns the channel items are delivered on. It is closed when Close is
+// called, or when the hub closes the subscription under CloseOnLag.
+func (s *Subscription[T]) C() <-chan T {
+	return s.queue
+}
+
+// Err returns the reason this subscription was closed by the hub, if any.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close unsubscribes from the hub and closes the underlying channel. It is
+// safe to call Close more than once.
+func (s *Subscription[T]) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.unsubscribe(s.id)
+		close(s.queue)
+	})
+}
+
+func (s *Subscription[T]) closeWithErr(err error) {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+		close(s.queue)
+	})
+}
+
+// hub fans a stream of items of type T out to many independent
+// Subscriptions. Publishing never blocks the caller: each subscription's
+// OverflowPolicy decides what happens when its queue is already full.
+type hub[T any] struct {
+	mu     sync.Mutex
+	nextID int64
+	subs   map[int64]*Subscription[T]
+}
+
+func newHub[T any]() *hub[T] {
+	return &hub[T]{subs: make(map[int64]*Subscription[T])}
+}
+
+// subscribe registers a new Subscription with the given buffer size,
+// overflow policy and optional filter. A nil filter receives every item.
+func (h *hub[T]) subscribe(bufSize int, policy OverflowPolicy, filter func(T) bool) *Subscription[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	sub := &Subscription[T]{
+		id:     h.nextID,
+		hub:    h,
+		queue:  make(chan T, bufSize),
+		policy: policy,
+		filter: filter,
+	}
+	h.subs[sub.id] = sub
+	return sub
+}
+
+func (h *hub[T]) unsubscribe(id int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subs, id)
+}
+
+// publish fans the given item out to every matching subscription. It never
+// blocks on a slow subscriber: instead, the subscription's own
+// OverflowPolicy is applied to decide what happens to it. It reports
+// whether item was actually enqueued for at least one subscription, so a
+// caller that needs delivery to be guaranteed (rather than best-effort) can
+// notice when every matching subscriber dropped it.
+func (h *hub[T]) publish(item T) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delivered := false
+	for id, sub := range h.subs {
+		if sub.filter != nil && !sub.filter(item) {
+			continue
+		}
+
+		select {
+		case sub.queue <- item:
+			delivered = true
+			continue
+		default:
+		}
+
+		switch sub.policy {
+		case DropNewest:
+			// Keep the subscriber's queue as-is; drop the incoming item.
+		case CloseOnLag:
+			sub.closeWithErr(ErrSubscriptionLagged)
+			delete(h.subs, id)
+		default: // DropOldest
+			select {
+			case <-sub.queue:
+			default:
+			}
+			select {
+			case sub.queue <- item:
+				delivered = true
+			default:
+			}
+		}
+	}
+	return delivered
+}
+
+// len returns the number of active subscriptions.
+func (h *hub[T]) len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHubDropOldest(t *testing.T) {
+	h := newHub[int]()
+	sub := h.subscribe(2, DropOldest, nil)
+
+	h.publish(1)
+	h.publish(2)
+	h.publish(3) // queue is full; 1 should be dropped for 3.
+
+	if got := <-sub.C(); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+	if got := <-sub.C(); got != 3 {
+		t.Fatalf("got %d, want 3", got)
+	}
+}
+
+func TestHubDropNewest(t *testing.T) {
+	h := newHub[int]()
+	sub := h.subscribe(2, DropNewest, nil)
+
+	h.publish(1)
+	h.publish(2)
+	h.publish(3) // queue is full; 3 should be dropped instead of 1.
+
+	if got := <-sub.C(); got != 1 {
+		t.Fatalf("got %d, want 1", got)
+	}
+	if got := <-sub.C(); got != 2 {
+		t.Fatalf("got %d, want 2", got)
+	}
+}
+
+func TestHubCloseOnLag(t *testing.T) {
+	h := newHub[int]()
+	sub := h.subscribe(1, CloseOnLag, nil)
+
+	h.publish(1)
+	h.publish(2) // queue is full; the subscription should be closed.
+
+	if got, ok := <-sub.C(); !ok || got != 1 {
+		t.Fatalf("got (%d, %v), want (1, true) for the already-queued item", got, ok)
+	}
+	if _, ok := <-sub.C(); ok {
+		t.Fatal("expected the channel to be closed after the queued item")
+	}
+	if !errors.Is(sub.Err(), ErrSubscriptionLagged) {
+		t.Fatalf("Err() = %v, want ErrSubscriptionLagged", sub.Err())
+	}
+
+	if h.len() != 0 {
+		t.Fatalf("hub should have removed the closed subscription, len = %d", h.len())
+	}
+}
+
+func TestHubFilter(t *testing.T) {
+	h := newHub[int]()
+	even := h.subscribe(4, DropOldest, func(v int) bool { return v%2 == 0 })
+
+	h.publish(1)
+	h.publish(2)
+	h.publish(3)
+	h.publish(4)
+	close(even.queue)
+
+	var got []int
+	for v := range even.queue {
+		got = append(got, v)
+	}
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Fatalf("got %v, want [2 4]", got)
+	}
+}
+
+func TestHubPublishReportsDelivery(t *testing.T) {
+	h := newHub[int]()
+
+	if h.publish(1) {
+		t.Fatal("got delivered = true with no subscribers, want false")
+	}
+
+	dropNewest := h.subscribe(1, DropNewest, nil)
+	if !h.publish(2) {
+		t.Fatal("got delivered = false for the first item, want true")
+	}
+	if h.publish(3) {
+		t.Fatal("got delivered = true for an item DropNewest discarded, want false")
+	}
+	<-dropNewest.C() // drain; dropNewest stays subscribed for the rest of the test.
+
+	closeOnLag := h.subscribe(1, CloseOnLag, nil)
+	h.publish(4) // fills the 1-item queue.
+	if h.publish(5) {
+		t.Fatal("got delivered = true for an item that tore down the subscription, want false")
+	}
+	if !errors.Is(closeOnLag.Err(), ErrSubscriptionLagged) {
+		t.Fatalf("Err() = %v, want ErrSubscriptionLagged", closeOnLag.Err())
+	}
+
+	dropOldest := h.subscribe(1, DropOldest, nil)
+	h.publish(6)
+	if !h.publish(7) {
+		t.Fatal("got delivered = false for an item DropOldest made room for, want true")
+	}
+	if got := <-dropOldest.C(); got != 7 {
+		t.Fatalf("got %d, want 7", got)
+	}
+}
+
+func TestSubscriptionCloseIsIdempotent(t *testing.T) {
+	h := newHub[int]()
+	sub := h.subscribe(1, DropOldest, nil)
+
+	sub.Close()
+	sub.Close() // must not panic on double Close.
+
+	if h.len() != 0 {
+		t.Fatalf("hub should have no subscribers left, len = %d", h.len())
+	}
+}
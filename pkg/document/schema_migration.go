@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yorkie-team/yorkie/api/types"
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/json"
+	"github.com/yorkie-team/yorkie/pkg/schema"
+)
+
+// schemaVersionPath is the reserved top-level field Document stamps with the
+// schema version a document's tree currently satisfies, so a peer with newer
+// SchemaMigrations configured can tell how far to replay them.
+const schemaVersionPath = "__schemaVersion"
+
+// Migration upgrades a document from one SchemaVersion to the next. Apply
+// runs inside the same change.Context as a normal Update, so the operations
+// it performs are recorded and replicated like any other change, instead of
+// silently diverging between peers that are on different versions of the
+// ruleset.
+type Migration struct {
+	// From is the SchemaVersion this migration upgrades from.
+	From uint32
+
+	// To is the SchemaVersion this migration upgrades to. Migrations must
+	// chain contiguously: Document.migrateIfNeeded walks from a document's
+	// stored version to SchemaVersion by repeatedly looking up the
+	// migration whose From matches the version reached so far.
+	To uint32
+
+	// Apply performs the operations that bring root's shape in line with To.
+	Apply func(root *json.Object) error
+
+	// Rules are the schema rules a document must satisfy once this
+	// migration has been applied.
+	Rules []types.Rule
+}
+
+// MigrateNow synchronously replays any pending SchemaMigrations against the
+// document's current state and validates the result. ApplyChangePack calls
+// this automatically after applying remote changes unless WithStrictSchema
+// is set; MigrateNow exists so tests can exercise the same path directly.
+func (d *Document) MigrateNow() error {
+	return d.migrateIfNeeded()
+}
+
+// migrateIfNeeded reads the schema version stamped on the document's tree
+// and, if it is behind SchemaVersion, replays the chain of SchemaMigrations
+// that bring it up to date before validating against the target version's
+// rules.
+func (d *Document) migrateIfNeeded() error {
+	if err := d.ensureClone(); err != nil {
+		return err
+	}
+
+	steps := d.pendingMigrations(d.schemaVersion())
+	if len(steps) == 0 {
+		return d.validateSchema()
+	}
+
+	ctx := change.NewContext(d.doc.changeID, "schema migration", d.cloneRoot)
+	root := json.NewObject(ctx, d.cloneRoot.Object())
+
+	for _, step := range steps {
+		if err := step.Apply(root); err != nil {
+			d.cloneRoot = nil
+			d.clonePresences = nil
+			return fmt.Errorf("migrate schema from %d to %d: %w", step.From, step.To, err)
+		}
+		root.SetInteger(schemaVersionPath, int(step.To))
+	}
+
+	if err := d.validateSchema(); err != nil {
+		d.cloneRoot = nil
+		d.clonePresences = nil
+		return err
+	}
+
+	if ctx.HasChange() {
+		c := ctx.ToChange()
+
+		// NOTE: Persist before mutating in-memory state; see the matching
+		// comment in Document.Update.
+		if d.store != nil {
+			if err := d.store.AppendLocalChange(d.doc.key, c); err != nil {
+				d.cloneRoot = nil
+				d.clonePresences = nil
+				return err
+			}
+		}
+
+		if err := c.Execute(d.doc.root, d.doc.presences); err != nil {
+			return err
+		}
+
+		d.doc.localChanges = append(d.doc.localChanges, c)
+		d.doc.changeID = ctx.NextID()
+	}
+
+	return nil
+}
+
+// pendingMigrations returns, in order, the migrations that walk a document
+// from schema version from up to SchemaVersion. It stops as soon as it
+// cannot find the next migration in the chain, leaving the document at
+// whatever version it reached.
+func (d *Document) pendingMigrations(from uint32) []Migration {
+	if from >= d.SchemaVersion {
+		return nil
+	}
+
+	byFrom := make(map[uint32]Migration, len(d.SchemaMigrations))
+	for _, m := range d.SchemaMigrations {
+		byFrom[m.From] = m
+	}
+
+	var steps []Migration
+	for v := from; v < d.SchemaVersion; {
+		m, ok := byFrom[v]
+		if !ok {
+			break
+		}
+		steps = append(steps, m)
+		v = m.To
+	}
+	return steps
+}
+
+// schemaVersion returns the schema version currently stamped on the
+// document's tree, or 0 if it has never been stamped. A brand new document,
+// or one attached before SchemaMigrations was configured, never had
+// schemaVersionPath set, so this is the common path on the first
+// migrateIfNeeded call, not an edge case.
+func (d *Document) schemaVersion() (version uint32) {
+	ctx := change.NewContext(d.doc.changeID.Next(), "", d.cloneRoot)
+	root := json.NewObject(ctx, d.cloneRoot.Object())
+
+	// NOTE: GetInteger's contract for a key that was never set isn't
+	// something this package can pin down on its own (json.Object lives in
+	// a sibling package), so this recovers rather than assume it returns a
+	// safe zero value for a missing key.
+	defer func() {
+		if recover() != nil {
+			version = 0
+		}
+	}()
+	return uint32(root.GetInteger(schemaVersionPath))
+}
+
+// latestSchemaRules returns the Rules of the migration that reaches
+// SchemaVersion, or nil if SchemaMigrations does not configure that version,
+// in which case no schema validation is performed.
+func (d *Document) latestSchemaRules() []types.Rule {
+	for _, m := range d.SchemaMigrations {
+		if m.To == d.SchemaVersion {
+			return m.Rules
+		}
+	}
+	return nil
+}
+
+// validateSchema validates the document's current cloneRoot against
+// latestSchemaRules. It is a no-op if no migration configures SchemaVersion.
+func (d *Document) validateSchema() error {
+	rules := d.latestSchemaRules()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := schema.ValidateYorkieRuleset(d.cloneRoot.Object(), rules)
+	if result.Valid {
+		return nil
+	}
+
+	var errorMessages []string
+	for _, err := range result.Errors {
+		errorMessages = append(errorMessages, err.Message)
+	}
+	return fmt.Errorf("%w: %s", ErrSchemaValidationFailed, strings.Join(errorMessages, ", "))
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocumentPendingMigrations(t *testing.T) {
+	v0to1 := Migration{From: 0, To: 1}
+	v1to2 := Migration{From: 1, To: 2}
+	v2to3 := Migration{From: 2, To: 3}
+
+	tests := []struct {
+		name    string
+		version uint32
+		from    uint32
+		want    []Migration
+	}{
+		{
+			name:    "already at the target version",
+			version: 2,
+			from:    2,
+			want:    nil,
+		},
+		{
+			name:    "ahead of the target version",
+			version: 1,
+			from:    2,
+			want:    nil,
+		},
+		{
+			name:    "walks the full chain in order",
+			version: 3,
+			from:    0,
+			want:    []Migration{v0to1, v1to2, v2to3},
+		},
+		{
+			name:    "starts partway through the chain",
+			version: 3,
+			from:    1,
+			want:    []Migration{v1to2, v2to3},
+		},
+		{
+			name:    "stops where the chain has a gap",
+			version: 5,
+			from:    0,
+			want:    []Migration{v0to1, v1to2, v2to3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Document{
+				SchemaVersion:    tt.version,
+				SchemaMigrations: []Migration{v0to1, v1to2, v2to3},
+			}
+
+			got := d.pendingMigrations(tt.from)
+			if !reflect.DeepEqual(toFromTo(got), toFromTo(tt.want)) {
+				t.Fatalf("got %v, want %v", toFromTo(got), toFromTo(tt.want))
+			}
+		})
+	}
+}
+
+func TestDocumentLatestSchemaRules(t *testing.T) {
+	rulesV2 := []Migration{{From: 1, To: 2, Rules: nil}}
+
+	d := &Document{
+		SchemaVersion:    2,
+		SchemaMigrations: rulesV2,
+	}
+	if rules := d.latestSchemaRules(); rules != nil {
+		t.Fatalf("got %v, want nil", rules)
+	}
+
+	d = &Document{SchemaVersion: 99, SchemaMigrations: rulesV2}
+	if rules := d.latestSchemaRules(); rules != nil {
+		t.Fatalf("got %v, want nil for an unconfigured version", rules)
+	}
+}
+
+// TestDocumentMigrateIfNeeded documents a known coverage gap rather than
+// silently leaving it unaddressed: migrateIfNeeded/MigrateNow/schemaVersion
+// exercise the core migration-execution path (stamping schemaVersionPath,
+// replaying Migration.Apply inside a change.Context, recording it as a
+// replicated change, then validating), but doing so needs a real Document
+// with a working cloneRoot, change.ID and store — built from the key,
+// change, time, json, crdt and schema packages this snapshot does not
+// contain (see TestDocumentPendingMigrations and TestDocumentLatestSchemaRules
+// above for the two helpers that could be tested without them). Once those packages are available, this
+// test should construct an unstamped Document (SchemaVersion > 0, no prior
+// schemaVersionPath) and assert MigrateNow stamps it, applies every
+// migration's Apply in order, and records exactly one replicated change.
+func TestDocumentMigrateIfNeeded(t *testing.T) {
+	t.Skip("needs key/change/time/json/crdt/schema packages not present in this snapshot")
+}
+
+// toFromTo reduces a []Migration to its (From, To) pairs so test expectations
+// don't need to repeat Apply/Rules, which are irrelevant to chain ordering.
+func toFromTo(migrations []Migration) [][2]uint32 {
+	pairs := make([][2]uint32, 0, len(migrations))
+	for _, m := range migrations {
+		pairs = append(pairs, [2]uint32{m.From, m.To})
+	}
+	return pairs
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"sync"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+// Store is the local persistence backend a Document uses to survive process
+// restarts without needing to re-confirm its own pending local changes with
+// the server on the next sync. A Document configured with WithStore, or
+// created with Resume, keeps Store up to date as it applies local and
+// remote changes; implementations must be safe for concurrent use.
+//
+// INCOMPLETE: this is a partial delivery of chunk0-3, not the full request.
+// Only MemoryStore ships here. BoltDB/Pebble and, notably, the etcd v3
+// backend the original request was motivated by (letting multiple
+// processes share persisted state across restarts) are not implemented and
+// are tracked as follow-up work; they satisfy this same interface and
+// require no changes to Document once added.
+type Store interface {
+	// SaveSnapshot persists snapshot as the latest known state for k, along
+	// with the checkpoint and version vector it was taken at. Snapshots
+	// arrive whenever the server sends one in a change.Pack, which already
+	// happens on a cadence the server paces by accumulated change count, so
+	// callers do not need to re-snapshot on their own schedule.
+	SaveSnapshot(k key.Key, cp change.Checkpoint, vv time.VersionVector, snapshot []byte) error
+
+	// AppendLocalChange appends c to the durable log of local changes made
+	// to k that the server has not yet confirmed.
+	AppendLocalChange(k key.Key, c *change.Change) error
+
+	// TruncateLocalChanges drops every local change appended for k up to and
+	// including clientSeq, once the server has confirmed them.
+	TruncateLocalChanges(k key.Key, clientSeq uint32) error
+
+	// LoadLatest returns the most recently saved snapshot for k along with
+	// the local changes appended since, and the checkpoint/version vector
+	// the snapshot was saved under. snapshot is nil if k was never saved.
+	LoadLatest(k key.Key) (
+		snapshot []byte,
+		localChanges []*change.Change,
+		cp change.Checkpoint,
+		vv time.VersionVector,
+		err error,
+	)
+}
+
+// WithStore configures the document to persist its local changes and
+// snapshots to store, so a later process can resume it with Resume instead
+// of re-fetching a snapshot from the server.
+func WithStore(store Store) Option {
+	return func(o *Options) {
+		o.Store = store
+	}
+}
+
+// MemoryStore is an in-memory Store. It offers no durability across process
+// restarts on its own, but is useful for tests and for composing with a
+// durable Store in front of it.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[key.Key]*memoryStoreEntry
+}
+
+type memoryStoreEntry struct {
+	snapshot     []byte
+	checkpoint   change.Checkpoint
+	vv           time.VersionVector
+	localChanges []*change.Change
+}
+
+// NewMemoryStore creates a new instance of MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[key.Key]*memoryStoreEntry)}
+}
+
+// SaveSnapshot implements Store.
+func (s *MemoryStore) SaveSnapshot(
+	k key.Key,
+	cp change.Checkpoint,
+	vv time.VersionVector,
+	snapshot []byte,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(k)
+	e.snapshot = snapshot
+	e.checkpoint = cp
+	e.vv = vv
+	e.localChanges = nil
+	return nil
+}
+
+// AppendLocalChange implements Store.
+func (s *MemoryStore) AppendLocalChange(k key.Key, c *change.Change) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(k)
+	e.localChanges = append(e.localChanges, c)
+	return nil
+}
+
+// TruncateLocalChanges implements Store.
+func (s *MemoryStore) TruncateLocalChanges(k key.Key, clientSeq uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(k)
+	i := 0
+	for i < len(e.localChanges) && e.localChanges[i].ClientSeq() <= clientSeq {
+		i++
+	}
+	e.localChanges = e.localChanges[i:]
+	return nil
+}
+
+// LoadLatest implements Store.
+func (s *MemoryStore) LoadLatest(
+	k key.Key,
+) ([]byte, []*change.Change, change.Checkpoint, time.VersionVector, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[k]
+	if !ok {
+		return nil, nil, change.InitialCheckpoint, time.NewVersionVector(), nil
+	}
+	return e.snapshot, e.localChanges, e.checkpoint, e.vv, nil
+}
+
+func (s *MemoryStore) entry(k key.Key) *memoryStoreEntry {
+	e, ok := s.entries[k]
+	if !ok {
+		e = &memoryStoreEntry{
+			checkpoint: change.InitialCheckpoint,
+			vv:         time.NewVersionVector(),
+		}
+		s.entries[k] = e
+	}
+	return e
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yorkie-team/yorkie/pkg/document/change"
+	"github.com/yorkie-team/yorkie/pkg/document/key"
+	"github.com/yorkie-team/yorkie/pkg/document/time"
+)
+
+func TestMemoryStoreLoadLatestUnseenKey(t *testing.T) {
+	s := NewMemoryStore()
+
+	snapshot, localChanges, cp, vv, err := s.LoadLatest(key.Key("unseen-doc"))
+	if err != nil {
+		t.Fatalf("LoadLatest returned an error for an unseen key: %v", err)
+	}
+	if snapshot != nil {
+		t.Fatalf("got snapshot %v, want nil", snapshot)
+	}
+	if localChanges != nil {
+		t.Fatalf("got localChanges %v, want nil", localChanges)
+	}
+	if cp != change.InitialCheckpoint {
+		t.Fatalf("got checkpoint %v, want change.InitialCheckpoint", cp)
+	}
+	if !reflect.DeepEqual(vv, time.NewVersionVector()) {
+		t.Fatalf("got version vector %v, want a fresh one", vv)
+	}
+}
+
+func TestMemoryStoreSaveSnapshotRoundTrip(t *testing.T) {
+	s := NewMemoryStore()
+	k := key.Key("doc-1")
+	cp := change.InitialCheckpoint
+	vv := time.NewVersionVector()
+	want := []byte(`{"k":"v"}`)
+
+	if err := s.SaveSnapshot(k, cp, vv, want); err != nil {
+		t.Fatalf("SaveSnapshot returned an error: %v", err)
+	}
+
+	got, localChanges, gotCp, gotVV, err := s.LoadLatest(k)
+	if err != nil {
+		t.Fatalf("LoadLatest returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got snapshot %v, want %v", got, want)
+	}
+	if localChanges != nil {
+		t.Fatalf("got localChanges %v, want nil: SaveSnapshot should clear changes it now supersedes", localChanges)
+	}
+	if gotCp != cp {
+		t.Fatalf("got checkpoint %v, want %v", gotCp, cp)
+	}
+	if !reflect.DeepEqual(gotVV, vv) {
+		t.Fatalf("got version vector %v, want %v", gotVV, vv)
+	}
+}
+
+// TestMemoryStoreAppendAndTruncateLocalChanges, and an integration test for
+// Resume replaying pending local changes over a persisted snapshot, are
+// known gaps left undone here rather than silently skipped: both need real
+// *change.Change values, which this snapshot has no way to construct
+// without the change package's own constructors (change.New and friends),
+// none of which exist in this tree. Once those are available, add:
+//   - AppendLocalChange/TruncateLocalChanges round-tripping a handful of
+//     changes, confirming truncation drops everything up to and including
+//     the given ClientSeq and nothing more.
+//   - Resume (via a Document created with WithStore) restoring a document
+//     with: no prior state, a snapshot only, a snapshot plus pending local
+//     changes, and pending local changes with no snapshot at all.
+func TestMemoryStoreAppendAndTruncateLocalChanges(t *testing.T) {
+	t.Skip("needs a *change.Change constructor not present in this snapshot")
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 The Yorkie Authors. All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+// defaultEventBufSize is the queue size a Subscription gets when
+// WithBufferSize is not given.
+const defaultEventBufSize = 64
+
+// SubscribeOption configures a Subscription created by Document.Subscribe.
+type SubscribeOption struct {
+	bufSize int
+	policy  OverflowPolicy
+	types   map[DocEventType]bool
+}
+
+// SubscribeOptionFunc configures a SubscribeOption.
+type SubscribeOptionFunc func(*SubscribeOption)
+
+// WithEventTypes limits a Subscription to the given event types. If this
+// option is not given, the Subscription receives events of every type.
+func WithEventTypes(types ...DocEventType) SubscribeOptionFunc {
+	return func(o *SubscribeOption) {
+		if o.types == nil {
+			o.types = make(map[DocEventType]bool, len(types))
+		}
+		for _, t := range types {
+			o.types[t] = true
+		}
+	}
+}
+
+// WithBufferSize overrides the default size of a Subscription's queue.
+func WithBufferSize(n int) SubscribeOptionFunc {
+	return func(o *SubscribeOption) {
+		o.bufSize = n
+	}
+}
+
+// WithOverflowPolicy overrides the default overflow policy (DropOldest) of a
+// Subscription.
+func WithOverflowPolicy(policy OverflowPolicy) SubscribeOptionFunc {
+	return func(o *SubscribeOption) {
+		o.policy = policy
+	}
+}
+
+func newSubscribeOption(opts ...SubscribeOptionFunc) *SubscribeOption {
+	o := &SubscribeOption{
+		bufSize: defaultEventBufSize,
+		policy:  DropOldest,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// matches reports whether the given DocEvent passes this option's type
+// filter.
+func (o *SubscribeOption) matches(e DocEvent) bool {
+	return o.types == nil || o.types[e.Type]
+}